@@ -0,0 +1,33 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package alpha hosts kubebuilder's alpha-stability subcommands: features
+// that are still evolving and may change or be removed without notice.
+package alpha
+
+import "github.com/spf13/cobra"
+
+// Command returns the `alpha` command, grouping kubebuilder's alpha
+// subcommands under it. The root CLI is expected to mount this alongside
+// its other top-level commands.
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alpha",
+		Short: "Access alpha features",
+	}
+	cmd.AddCommand(newUpgradePluginsCmd())
+	return cmd
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/config"
+	"sigs.k8s.io/kubebuilder/v3/pkg/plugin"
+)
+
+// projectFile is the name of the project configuration file that
+// upgrade-plugins reads and rewrites in place.
+const projectFile = "PROJECT"
+
+// newUpgradePluginsCmd returns the `alpha upgrade-plugins` command, which
+// walks every plugin key tracked in PROJECT and rewrites its configuration
+// using the migrators plugins have registered for it.
+func newUpgradePluginsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "upgrade-plugins",
+		Short: "Upgrade the plugin configs stored in a project's PROJECT file",
+		Long: `Upgrade-plugins rewrites the plugin configuration blocks in PROJECT using
+the config migrators plugins have registered for their own schema versions,
+so a project can move forward after a plugin bumps its config schema
+without the user hand-editing YAML.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpgradePlugins()
+		},
+	}
+}
+
+func runUpgradePlugins() error {
+	b, err := ioutil.ReadFile(projectFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", projectFile, err)
+	}
+
+	var versioned struct {
+		Version config.Version `json:"version"`
+	}
+	if err := yaml.Unmarshal(b, &versioned); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", projectFile, err)
+	}
+
+	c, err := config.New(versioned.Version)
+	if err != nil {
+		return fmt.Errorf("failed to build a config for version %s: %w", versioned.Version, err)
+	}
+	if err := c.Unmarshal(b); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", projectFile, err)
+	}
+
+	upgrader, ok := c.(config.PluginConfigUpgrader)
+	if !ok {
+		return fmt.Errorf("project config version %s does not support plugin config upgrades", versioned.Version)
+	}
+
+	for _, key := range upgrader.PluginConfigKeys() {
+		target, hasMigrators, err := plugin.ConfigMigratorTarget(key)
+		if err != nil {
+			return fmt.Errorf("failed to resolve plugin config target version for %q: %w", key, err)
+		}
+		if !hasMigrators {
+			continue
+		}
+
+		migrateToTarget := func(old []byte) ([]byte, error) {
+			current, err := plugin.CurrentConfigVersion(old)
+			if err != nil {
+				return nil, err
+			}
+
+			chain, err := plugin.ConfigMigratorChain(key, current, target)
+			if err != nil {
+				return nil, err
+			}
+
+			next := old
+			for _, migrate := range chain {
+				if next, err = migrate(next); err != nil {
+					return nil, err
+				}
+			}
+			return next, nil
+		}
+
+		if err := upgrader.UpgradePluginConfig(key, migrateToTarget); err != nil {
+			return fmt.Errorf("failed to upgrade plugin config %q: %w", key, err)
+		}
+	}
+
+	out, err := c.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", projectFile, err)
+	}
+
+	if err := ioutil.WriteFile(projectFile, out, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", projectFile, err)
+	}
+
+	return nil
+}
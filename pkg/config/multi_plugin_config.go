@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MultiPluginConfig is an optional Config capability for storing more than
+// one configuration instance under the same plugin key, e.g. two
+// independently configured instances of the same scaffolding plugin. Config
+// versions predating this support won't implement it, so CLI flags that
+// accept a PluginConfigIndex must type-assert for it first.
+type MultiPluginConfig interface {
+	// DecodePluginConfigs decodes every instance stored under key into
+	// configObjs, which must be a pointer to a slice.
+	DecodePluginConfigs(key string, configObjs interface{}) error
+
+	// AppendPluginConfig adds configObj as a new instance under key,
+	// preserving any instances already stored there.
+	AppendPluginConfig(key string, configObj interface{}) error
+
+	// DecodePluginConfigAt decodes the single instance addressed by index
+	// into configObj. It is a no-op if index is out of range.
+	DecodePluginConfigAt(index PluginConfigIndex, configObj interface{}) error
+
+	// EncodePluginConfigAt sets the instance addressed by index to
+	// configObj, extending the stored instances under index.Key with empty
+	// entries as needed to reach it.
+	EncodePluginConfigAt(index PluginConfigIndex, configObj interface{}) error
+}
+
+// PluginConfigIndex identifies a single instance of a plugin's configuration
+// when more than one has been scaffolded under the same plugin key, e.g.
+// "sampleplugin.example.com/v1[1]" addresses the second instance.
+type PluginConfigIndex struct {
+	Key   string
+	Index int
+}
+
+// ParsePluginConfigIndex parses a raw "key" or "key[index]" CLI flag value
+// into the plugin key and instance index it addresses. A bare key addresses
+// index 0.
+func ParsePluginConfigIndex(raw string) (PluginConfigIndex, error) {
+	open := strings.IndexByte(raw, '[')
+	if open == -1 {
+		return PluginConfigIndex{Key: raw, Index: 0}, nil
+	}
+
+	if !strings.HasSuffix(raw, "]") {
+		return PluginConfigIndex{}, fmt.Errorf("malformed plugin config index %q: missing closing ']'", raw)
+	}
+
+	index, err := strconv.Atoi(raw[open+1 : len(raw)-1])
+	if err != nil {
+		return PluginConfigIndex{}, fmt.Errorf("malformed plugin config index %q: %w", raw, err)
+	}
+
+	return PluginConfigIndex{Key: raw[:open], Index: index}, nil
+}
+
+// String returns the canonical "key[index]" form, omitting the index suffix
+// for index 0 to match single-instance PROJECT files.
+func (i PluginConfigIndex) String() string {
+	if i.Index == 0 {
+		return i.Key
+	}
+	return fmt.Sprintf("%s[%d]", i.Key, i.Index)
+}
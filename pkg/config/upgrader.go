@@ -0,0 +1,33 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+// PluginConfigUpgrader is an optional Config capability for rewriting a
+// single plugin's persisted configuration in place, e.g. when a plugin
+// bumps its own config schema version. It is only implemented by config
+// versions that track plugins, so the `alpha upgrade-plugins` command must
+// type-assert for it rather than calling it through Config directly.
+type PluginConfigUpgrader interface {
+	// PluginConfigKeys returns the plugin keys currently tracked in the
+	// config.
+	PluginConfigKeys() []string
+
+	// UpgradePluginConfig rewrites the plugin config stored under key by
+	// passing its current raw bytes through migrator and persisting the
+	// result. It is a no-op if key is not tracked.
+	UpgradePluginConfig(key string, migrator func(old []byte) (new []byte, err error)) error
+}
@@ -17,9 +17,13 @@ limitations under the License.
 package v3alpha
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	"golang.org/x/mod/module"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/kubebuilder/v3/pkg/config"
@@ -52,12 +56,57 @@ type cfg struct {
 }
 
 // PluginConfigs holds a set of arbitrary plugin configuration objects mapped by plugin key.
+// A key may address either a single configuration instance or several: on
+// disk, a single instance is stored as a bare mapping for backwards
+// compatibility with existing PROJECT files, while more than one instance
+// is stored as a sequence.
 // TODO: do not export this once internalconfig has merged with config
-type PluginConfigs map[string]pluginConfig
+type PluginConfigs map[string][]pluginConfig
 
 // pluginConfig is an arbitrary plugin configuration object.
 type pluginConfig interface{}
 
+// MarshalJSON implements json.Marshaler. A key with a single instance is
+// emitted as a bare mapping; a key with more than one is emitted as a
+// sequence.
+func (p PluginConfigs) MarshalJSON() ([]byte, error) {
+	raw := make(map[string]interface{}, len(p))
+	for key, instances := range p {
+		if len(instances) == 1 {
+			raw[key] = instances[0]
+		} else {
+			raw[key] = instances
+		}
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Each key's value may be either
+// a single plugin config object or a sequence of them.
+func (p *PluginConfigs) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	out := make(PluginConfigs, len(raw))
+	for key, msg := range raw {
+		var instances []pluginConfig
+		if err := json.Unmarshal(msg, &instances); err != nil {
+			// The value isn't a sequence, so it must be a single instance.
+			var instance pluginConfig
+			if err := json.Unmarshal(msg, &instance); err != nil {
+				return fmt.Errorf("failed to unmarshal plugin config %q: %w", key, err)
+			}
+			instances = []pluginConfig{instance}
+		}
+		out[key] = instances
+	}
+
+	*p = out
+	return nil
+}
+
 // New returns a new config.Config
 func New() config.Config {
 	return &cfg{Version: Version}
@@ -280,27 +329,137 @@ func (c cfg) resourceAPIVersionCompatible(verType, version string) bool {
 	return true
 }
 
-// DecodePluginConfig implements config.Config
+// Validate implements config.Validator
+func (c cfg) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	if c.Domain != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(c.Domain) {
+			errs = append(errs, field.Invalid(field.NewPath("domain"), c.Domain, msg))
+		}
+	}
+
+	if c.Name != "" {
+		for _, msg := range validation.IsDNS1123Label(c.Name) {
+			errs = append(errs, field.Invalid(field.NewPath("projectName"), c.Name, msg))
+		}
+	}
+
+	if c.Repository == "" {
+		errs = append(errs, field.Required(field.NewPath("repo"), "repository must be a valid Go module path"))
+	} else if err := module.CheckPath(c.Repository); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("repo"), c.Repository, err.Error()))
+	}
+
+	for i, res := range c.Resources {
+		p := field.NewPath("resources").Index(i)
+
+		if res.Group != strings.ToLower(res.Group) {
+			errs = append(errs, field.Invalid(p.Child("group"), res.Group, "group must be lowercase"))
+		}
+		if res.Version == "" {
+			errs = append(errs, field.Required(p.Child("version"), "version must not be empty"))
+		}
+		if res.Kind == "" {
+			errs = append(errs, field.Required(p.Child("kind"), "kind must not be empty"))
+		}
+
+		if res.API != nil && res.API.CRDVersion != "" && !c.resourceAPIVersionCompatible("crd", res.API.CRDVersion) {
+			errs = append(errs, field.Invalid(p.Child("api", "crdVersion"), res.API.CRDVersion,
+				"crdVersion must match the other tracked resources"))
+		}
+		if res.Webhooks != nil && res.Webhooks.WebhookVersion != "" &&
+			!c.resourceAPIVersionCompatible("webhook", res.Webhooks.WebhookVersion) {
+			errs = append(errs, field.Invalid(p.Child("webhooks", "webhookVersion"), res.Webhooks.WebhookVersion,
+				"webhookVersion must match the other tracked resources"))
+		}
+	}
+
+	return errs
+}
+
+// DecodePluginConfig implements config.Config. If key addresses more than
+// one instance, only the first is decoded.
 func (c cfg) DecodePluginConfig(key string, configObj interface{}) error {
-	if len(c.Plugins) == 0 {
+	instances, hasKey := c.Plugins[key]
+	if !hasKey || len(instances) == 0 {
+		return nil
+	}
+
+	b, err := yaml.Marshal(instances[0])
+	if err != nil {
+		return fmt.Errorf("failed to convert extra fields object to bytes: %w", err)
+	}
+	if err := yaml.Unmarshal(b, configObj); err != nil {
+		return fmt.Errorf("failed to unmarshal extra fields object: %w", err)
+	}
+
+	return nil
+}
+
+// DecodePluginConfigs implements config.MultiPluginConfig. configObjs must
+// be a pointer to a slice; it is decoded with every instance stored under
+// key, in the order they were appended.
+func (c cfg) DecodePluginConfigs(key string, configObjs interface{}) error {
+	instances, hasKey := c.Plugins[key]
+	if !hasKey {
+		return nil
+	}
+
+	b, err := yaml.Marshal(instances)
+	if err != nil {
+		return fmt.Errorf("failed to convert extra fields object to bytes: %w", err)
+	}
+	if err := yaml.Unmarshal(b, configObjs); err != nil {
+		return fmt.Errorf("failed to unmarshal extra fields object: %w", err)
+	}
+
+	return nil
+}
+
+// PluginConfigKeys implements config.PluginConfigUpgrader
+func (c cfg) PluginConfigKeys() []string {
+	keys := make([]string, 0, len(c.Plugins))
+	for key := range c.Plugins {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// UpgradePluginConfig implements config.PluginConfigUpgrader. Every instance
+// stored under key is migrated independently.
+func (c *cfg) UpgradePluginConfig(key string, migrator func(old []byte) (new []byte, err error)) error {
+	instances, hasKey := c.Plugins[key]
+	if !hasKey {
 		return nil
 	}
 
-	// Get the object blob by key and unmarshal into the object.
-	if pluginConfig, hasKey := c.Plugins[key]; hasKey {
-		b, err := yaml.Marshal(pluginConfig)
+	migrated := make([]pluginConfig, len(instances))
+	for i, instance := range instances {
+		old, err := yaml.Marshal(instance)
 		if err != nil {
-			return fmt.Errorf("failed to convert extra fields object to bytes: %w", err)
+			return fmt.Errorf("failed to convert %q plugin config to bytes: %w", key, err)
 		}
-		if err := yaml.Unmarshal(b, configObj); err != nil {
-			return fmt.Errorf("failed to unmarshal extra fields object: %w", err)
+
+		newConfig, err := migrator(old)
+		if err != nil {
+			return fmt.Errorf("failed to migrate %q plugin config: %w", key, err)
+		}
+
+		var fields map[string]interface{}
+		if err := yaml.Unmarshal(newConfig, &fields); err != nil {
+			return fmt.Errorf("failed to unmarshal migrated %q plugin config: %w", key, err)
 		}
+		migrated[i] = fields
 	}
+	c.Plugins[key] = migrated
 
 	return nil
 }
 
 // EncodePluginConfig will return an error if used on any project version < v3.
+// If key already addresses one or more instances, only the first is
+// overwritten; any instances appended via AppendPluginConfig are preserved.
 func (c *cfg) EncodePluginConfig(key string, configObj interface{}) error {
 	// Get object's bytes and set them under key in extra fields.
 	b, err := yaml.Marshal(configObj)
@@ -312,14 +471,86 @@ func (c *cfg) EncodePluginConfig(key string, configObj interface{}) error {
 		return fmt.Errorf("failed to unmarshal %T object bytes: %s", configObj, err)
 	}
 	if c.Plugins == nil {
-		c.Plugins = make(map[string]pluginConfig)
+		c.Plugins = make(PluginConfigs)
+	}
+	if len(c.Plugins[key]) == 0 {
+		c.Plugins[key] = []pluginConfig{fields}
+	} else {
+		c.Plugins[key][0] = fields
 	}
-	c.Plugins[key] = fields
+	return nil
+}
+
+// AppendPluginConfig implements config.MultiPluginConfig, adding configObj
+// as a new instance under key rather than replacing any existing ones.
+func (c *cfg) AppendPluginConfig(key string, configObj interface{}) error {
+	b, err := yaml.Marshal(configObj)
+	if err != nil {
+		return fmt.Errorf("failed to convert %T object to bytes: %s", configObj, err)
+	}
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(b, &fields); err != nil {
+		return fmt.Errorf("failed to unmarshal %T object bytes: %s", configObj, err)
+	}
+	if c.Plugins == nil {
+		c.Plugins = make(PluginConfigs)
+	}
+	c.Plugins[key] = append(c.Plugins[key], fields)
+	return nil
+}
+
+// DecodePluginConfigAt implements config.MultiPluginConfig
+func (c cfg) DecodePluginConfigAt(index config.PluginConfigIndex, configObj interface{}) error {
+	instances, hasKey := c.Plugins[index.Key]
+	if !hasKey || index.Index < 0 || index.Index >= len(instances) {
+		return nil
+	}
+
+	b, err := yaml.Marshal(instances[index.Index])
+	if err != nil {
+		return fmt.Errorf("failed to convert extra fields object to bytes: %w", err)
+	}
+	if err := yaml.Unmarshal(b, configObj); err != nil {
+		return fmt.Errorf("failed to unmarshal extra fields object: %w", err)
+	}
+
+	return nil
+}
+
+// EncodePluginConfigAt implements config.MultiPluginConfig
+func (c *cfg) EncodePluginConfigAt(index config.PluginConfigIndex, configObj interface{}) error {
+	if index.Index < 0 {
+		return fmt.Errorf("invalid plugin config index %d for key %q", index.Index, index.Key)
+	}
+
+	b, err := yaml.Marshal(configObj)
+	if err != nil {
+		return fmt.Errorf("failed to convert %T object to bytes: %s", configObj, err)
+	}
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(b, &fields); err != nil {
+		return fmt.Errorf("failed to unmarshal %T object bytes: %s", configObj, err)
+	}
+
+	if c.Plugins == nil {
+		c.Plugins = make(PluginConfigs)
+	}
+	instances := c.Plugins[index.Key]
+	for len(instances) <= index.Index {
+		instances = append(instances, nil)
+	}
+	instances[index.Index] = fields
+	c.Plugins[index.Key] = instances
+
 	return nil
 }
 
 // Marshal implements config.Config
 func (c cfg) Marshal() ([]byte, error) {
+	if errs := c.Validate(); len(errs) > 0 {
+		return nil, config.MarshalError{Err: errs.ToAggregate()}
+	}
+
 	for i, r := range c.Resources {
 		// If API is empty, omit it (prevents `api: {}`).
 		if r.API != nil && r.API.IsEmpty() {
@@ -345,5 +576,9 @@ func (c *cfg) Unmarshal(b []byte) error {
 		return config.UnmarshalError{Err: err}
 	}
 
+	if errs := c.Validate(); len(errs) > 0 {
+		return config.UnmarshalError{Err: errs.ToAggregate()}
+	}
+
 	return nil
 }
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v3alpha
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/config"
+	"sigs.k8s.io/kubebuilder/v3/pkg/model/resource"
+)
+
+func TestConfigV3Alpha(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Config v3alpha Suite")
+}
+
+var _ = Describe("PluginConfigs", func() {
+	It("marshals a single instance as a bare mapping, for backwards compatibility", func() {
+		c := cfg{
+			Version:    Version,
+			Repository: "example.com/foo",
+			Plugins: PluginConfigs{
+				"foo.example.com/v1": {map[string]interface{}{"one": "1"}},
+			},
+		}
+
+		b, err := c.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+
+		var raw map[string]interface{}
+		Expect(yaml.Unmarshal(b, &raw)).To(Succeed())
+		plugins, ok := raw["plugins"].(map[string]interface{})
+		Expect(ok).To(BeTrue())
+		Expect(plugins["foo.example.com/v1"]).To(BeAssignableToTypeOf(map[string]interface{}{}))
+	})
+
+	It("marshals multiple instances as a sequence", func() {
+		c := cfg{
+			Version:    Version,
+			Repository: "example.com/foo",
+			Plugins: PluginConfigs{
+				"foo.example.com/v1": {
+					map[string]interface{}{"one": "1"},
+					map[string]interface{}{"one": "2"},
+				},
+			},
+		}
+
+		b, err := c.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+
+		var raw map[string]interface{}
+		Expect(yaml.Unmarshal(b, &raw)).To(Succeed())
+		plugins, ok := raw["plugins"].(map[string]interface{})
+		Expect(ok).To(BeTrue())
+		Expect(plugins["foo.example.com/v1"]).To(BeAssignableToTypeOf([]interface{}{}))
+	})
+
+	It("round-trips single- and multi-instance keys through Marshal/Unmarshal", func() {
+		c := cfg{
+			Version:    Version,
+			Repository: "example.com/foo",
+			Plugins: PluginConfigs{
+				"single.example.com/v1": {map[string]interface{}{"one": "1"}},
+				"multi.example.com/v1": {
+					map[string]interface{}{"one": "1"},
+					map[string]interface{}{"one": "2"},
+				},
+			},
+		}
+
+		b, err := c.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+
+		var roundTripped cfg
+		Expect(roundTripped.Unmarshal(b)).To(Succeed())
+		Expect(roundTripped.Plugins["single.example.com/v1"]).To(HaveLen(1))
+		Expect(roundTripped.Plugins["multi.example.com/v1"]).To(HaveLen(2))
+	})
+
+	It("preserves other instances when EncodePluginConfig overwrites the first", func() {
+		c := cfg{Repository: "example.com/foo"}
+		Expect(c.AppendPluginConfig("foo.example.com/v1", map[string]interface{}{"one": "1"})).To(Succeed())
+		Expect(c.AppendPluginConfig("foo.example.com/v1", map[string]interface{}{"one": "2"})).To(Succeed())
+		Expect(c.EncodePluginConfig("foo.example.com/v1", map[string]interface{}{"one": "replaced"})).To(Succeed())
+
+		Expect(c.Plugins["foo.example.com/v1"]).To(HaveLen(2))
+
+		var instances []map[string]interface{}
+		Expect(c.DecodePluginConfigs("foo.example.com/v1", &instances)).To(Succeed())
+		Expect(instances[0]["one"]).To(Equal("replaced"))
+		Expect(instances[1]["one"]).To(Equal("2"))
+	})
+
+	It("addresses a specific instance via DecodePluginConfigAt/EncodePluginConfigAt", func() {
+		c := cfg{Repository: "example.com/foo"}
+		Expect(c.EncodePluginConfigAt(
+			config.PluginConfigIndex{Key: "foo.example.com/v1", Index: 1},
+			map[string]interface{}{"one": "second"},
+		)).To(Succeed())
+		Expect(c.Plugins["foo.example.com/v1"]).To(HaveLen(2))
+
+		var decoded map[string]interface{}
+		Expect(c.DecodePluginConfigAt(
+			config.PluginConfigIndex{Key: "foo.example.com/v1", Index: 1}, &decoded,
+		)).To(Succeed())
+		Expect(decoded["one"]).To(Equal("second"))
+	})
+})
+
+var _ = Describe("Validate", func() {
+	It("accepts a config with no project name set yet", func() {
+		c := cfg{Repository: "example.com/foo"}
+		Expect(c.Validate()).To(BeEmpty())
+	})
+
+	It("accepts a config with no domain set", func() {
+		c := cfg{Repository: "example.com/foo", Name: "foo"}
+		Expect(c.Validate()).To(BeEmpty())
+	})
+
+	It("rejects an invalid domain", func() {
+		c := cfg{Repository: "example.com/foo", Domain: "Not A Domain"}
+		Expect(c.Validate()).NotTo(BeEmpty())
+	})
+
+	It("rejects an invalid project name", func() {
+		c := cfg{Repository: "example.com/foo", Name: "Not_A_Name"}
+		Expect(c.Validate()).NotTo(BeEmpty())
+	})
+
+	It("rejects a missing repository", func() {
+		c := cfg{}
+		Expect(c.Validate()).NotTo(BeEmpty())
+	})
+
+	It("rejects a resource with an empty kind", func() {
+		c := cfg{
+			Repository: "example.com/foo",
+			Resources:  []resource.Resource{{GVK: resource.GVK{Group: "foo", Version: "v1"}}},
+		}
+		Expect(c.Validate()).NotTo(BeEmpty())
+	})
+})
@@ -0,0 +1,30 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "k8s.io/apimachinery/pkg/util/validation/field"
+
+// Validator is an optional Config capability for checking a config's own
+// fields for consistency, aggregating every problem found instead of
+// failing on the first one encountered. Config.Unmarshal and Config.Marshal
+// invoke it themselves where it's implemented, so most callers never need
+// to reach for it directly.
+type Validator interface {
+	// Validate returns every validation error found in the config, or an
+	// empty list if it is valid.
+	Validate() field.ErrorList
+}
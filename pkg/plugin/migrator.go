@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/config"
+)
+
+// ConfigMigrator upgrades a plugin's persisted PROJECT configuration from
+// one version of the plugin's own config schema to the next. The bytes it
+// returns must be tagged with toVersion under versionField so that a later
+// run can tell the migration has already happened.
+type ConfigMigrator func(old []byte) (new []byte, err error)
+
+// configMigratorEntry is a single registered schema transition for a plugin
+// key.
+type configMigratorEntry struct {
+	fromVersion config.Version
+	toVersion   config.Version
+	migrate     ConfigMigrator
+}
+
+// configMigrators holds the migrators plugins have registered for their own
+// config schema, keyed by plugin key.
+var configMigrators = map[string][]configMigratorEntry{}
+
+// RegisterConfigMigrator registers a migrator that upgrades the plugin
+// config stored under key from fromVersion to toVersion. Plugins should
+// call this from an init function whenever they bump their own config
+// schema version, mirroring how plugin and project config versions are
+// registered with config.Register.
+func RegisterConfigMigrator(key string, fromVersion, toVersion config.Version, migrator ConfigMigrator) {
+	configMigrators[key] = append(configMigrators[key], configMigratorEntry{
+		fromVersion: fromVersion,
+		toVersion:   toVersion,
+		migrate:     migrator,
+	})
+}
+
+// ConfigMigratorChain returns, in order, the migrators that must run to move
+// key's config from fromVersion to toVersion. It returns an empty chain
+// without error when fromVersion already equals toVersion, so a config that
+// has already been migrated is left untouched on a repeat run.
+func ConfigMigratorChain(key string, fromVersion, toVersion config.Version) ([]ConfigMigrator, error) {
+	return configMigratorChain(key, fromVersion, toVersion, map[config.Version]bool{fromVersion: true})
+}
+
+// configMigratorChain is ConfigMigratorChain's recursive implementation.
+// visited holds every version already on the current path so a cycle in a
+// plugin's registered migrators (a registration bug, not something a caller
+// can control) is reported as an error instead of recursing forever.
+func configMigratorChain(
+	key string, fromVersion, toVersion config.Version, visited map[config.Version]bool,
+) ([]ConfigMigrator, error) {
+	if fromVersion == toVersion {
+		return nil, nil
+	}
+
+	for _, entry := range configMigrators[key] {
+		if entry.fromVersion != fromVersion || visited[entry.toVersion] {
+			continue
+		}
+
+		branchVisited := make(map[config.Version]bool, len(visited)+1)
+		for v := range visited {
+			branchVisited[v] = true
+		}
+		branchVisited[entry.toVersion] = true
+
+		rest, err := configMigratorChain(key, entry.toVersion, toVersion, branchVisited)
+		if err != nil {
+			continue
+		}
+		return append([]ConfigMigrator{entry.migrate}, rest...), nil
+	}
+
+	return nil, fmt.Errorf(
+		"no config migrator chain registered for plugin %q from version %+v to %+v (or its migrators form a cycle)",
+		key, fromVersion, toVersion)
+}
+
+// ConfigMigratorTarget returns the version that the migrators registered for
+// key converge on - the one no migrator advances any further - along with
+// true, or false if no migrators are registered for key. upgrade-plugins
+// only supports a single linear upgrade chain per key, so it is an error for
+// more than one such terminal version to exist; a plugin author who needs a
+// branching graph must resolve it to a single target before registering.
+func ConfigMigratorTarget(key string) (config.Version, bool, error) {
+	entries := configMigrators[key]
+	if len(entries) == 0 {
+		return config.Version{}, false, nil
+	}
+
+	sources := make(map[config.Version]bool, len(entries))
+	for _, entry := range entries {
+		sources[entry.fromVersion] = true
+	}
+
+	seen := make(map[config.Version]bool, len(entries))
+	var targets []config.Version
+	for _, entry := range entries {
+		if sources[entry.toVersion] || seen[entry.toVersion] {
+			continue
+		}
+		seen[entry.toVersion] = true
+		targets = append(targets, entry.toVersion)
+	}
+
+	switch len(targets) {
+	case 0:
+		return config.Version{}, false, fmt.Errorf(
+			"no terminal config version found for plugin %q: its registered migrators form a cycle", key)
+	case 1:
+		return targets[0], true, nil
+	default:
+		return config.Version{}, false, fmt.Errorf(
+			"plugin %q has more than one terminal config version (%+v): "+
+				"upgrade-plugins only supports a single linear upgrade chain per key", key, targets)
+	}
+}
+
+// versionField is the reserved field within a plugin's persisted config
+// object that records which schema version it is currently at. Migrators
+// must set it in the bytes they return so CurrentConfigVersion can tell an
+// already-migrated config apart from one that still needs upgrading.
+const versionField = "configVersion"
+
+// CurrentConfigVersion extracts the schema version recorded under
+// versionField in a plugin config instance's raw bytes. An instance that
+// predates this system, or is otherwise missing the field, reports the zero
+// Version. ConfigMigratorChain gives the zero Version no special handling:
+// such an instance is only upgraded by a migrator registered from it.
+func CurrentConfigVersion(raw []byte) (config.Version, error) {
+	var tagged struct {
+		ConfigVersion config.Version `json:"configVersion,omitempty"`
+	}
+	if err := yaml.Unmarshal(raw, &tagged); err != nil {
+		return config.Version{}, fmt.Errorf("failed to read %s: %w", versionField, err)
+	}
+	return tagged.ConfigVersion, nil
+}
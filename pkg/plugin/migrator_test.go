@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/config"
+)
+
+func TestPlugin(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Plugin Suite")
+}
+
+var (
+	v1 = config.Version{Number: 1}
+	v2 = config.Version{Number: 2}
+	v3 = config.Version{Number: 3}
+)
+
+var _ = Describe("ConfigMigratorChain", func() {
+	BeforeEach(func() {
+		configMigrators = map[string][]configMigratorEntry{}
+	})
+
+	It("chains multiple registered migrators in order", func() {
+		var applied []string
+		RegisterConfigMigrator("foo.example.com/v1", v1, v2, func(old []byte) ([]byte, error) {
+			applied = append(applied, "v1->v2")
+			return old, nil
+		})
+		RegisterConfigMigrator("foo.example.com/v1", v2, v3, func(old []byte) ([]byte, error) {
+			applied = append(applied, "v2->v3")
+			return old, nil
+		})
+
+		chain, err := ConfigMigratorChain("foo.example.com/v1", v1, v3)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chain).To(HaveLen(2))
+
+		for _, migrate := range chain {
+			_, err := migrate(nil)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(applied).To(Equal([]string{"v1->v2", "v2->v3"}))
+	})
+
+	It("is a no-op when already at the target version", func() {
+		chain, err := ConfigMigratorChain("foo.example.com/v1", v2, v2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chain).To(BeEmpty())
+	})
+
+	It("errors when no chain connects the two versions", func() {
+		RegisterConfigMigrator("foo.example.com/v1", v1, v2, func(old []byte) ([]byte, error) { return old, nil })
+
+		_, err := ConfigMigratorChain("foo.example.com/v1", v1, v3)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("errors instead of recursing forever when migrators form a cycle", func() {
+		RegisterConfigMigrator("foo.example.com/v1", v1, v2, func(old []byte) ([]byte, error) { return old, nil })
+		RegisterConfigMigrator("foo.example.com/v1", v2, v1, func(old []byte) ([]byte, error) { return old, nil })
+
+		done := make(chan struct{})
+		var err error
+		go func() {
+			defer close(done)
+			_, err = ConfigMigratorChain("foo.example.com/v1", v1, v3)
+		}()
+
+		Eventually(done, "1s").Should(BeClosed())
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ConfigMigratorTarget", func() {
+	BeforeEach(func() {
+		configMigrators = map[string][]configMigratorEntry{}
+	})
+
+	It("returns the terminal version of a registered chain", func() {
+		RegisterConfigMigrator("foo.example.com/v1", v1, v2, func(old []byte) ([]byte, error) { return old, nil })
+		RegisterConfigMigrator("foo.example.com/v1", v2, v3, func(old []byte) ([]byte, error) { return old, nil })
+
+		target, ok, err := ConfigMigratorTarget("foo.example.com/v1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(target).To(Equal(v3))
+	})
+
+	It("reports no target when nothing is registered for the key", func() {
+		_, ok, err := ConfigMigratorTarget("unregistered.example.com/v1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("errors on a branching graph with more than one terminal version", func() {
+		RegisterConfigMigrator("foo.example.com/v1", v1, v2, func(old []byte) ([]byte, error) { return old, nil })
+		RegisterConfigMigrator("foo.example.com/v1", v1, v3, func(old []byte) ([]byte, error) { return old, nil })
+
+		_, ok, err := ConfigMigratorTarget("foo.example.com/v1")
+		Expect(ok).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("CurrentConfigVersion", func() {
+	It("defaults to the zero version when unset", func() {
+		v, err := CurrentConfigVersion([]byte(`{"one":"1"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(config.Version{}))
+	})
+
+	It("reads a previously tagged version", func() {
+		v, err := CurrentConfigVersion([]byte(`{"configVersion":{"number":2}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v.Number).To(Equal(2))
+	})
+})